@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"hash/fnv"
+)
+
+// BloomFilterBits/BloomFilterHashes size a ~16KB bloom filter with k=7 hash
+// functions, giving a low false-positive rate for the ~1000 hashes we track.
+const (
+	BloomFilterBits   = 16 * 1024 * 8
+	BloomFilterHashes = 7
+)
+
+// bloomFilter is a fixed-size bitset used as a fast, O(1) pre-check before
+// confirming membership against the exact hash set.
+type bloomFilter struct {
+	bits []byte
+}
+
+func newBloomFilter() *bloomFilter {
+	return &bloomFilter{bits: make([]byte, BloomFilterBits/8)}
+}
+
+// Add sets the bits corresponding to key's k hash positions.
+func (b *bloomFilter) Add(key string) {
+	h1, h2 := bloomHashes(key)
+	for i := 0; i < BloomFilterHashes; i++ {
+		pos := (h1 + uint64(i)*h2) % BloomFilterBits
+		b.bits[pos/8] |= 1 << (pos % 8)
+	}
+}
+
+// MightContain reports whether key may have been added. False positives are
+// possible; false negatives are not.
+func (b *bloomFilter) MightContain(key string) bool {
+	h1, h2 := bloomHashes(key)
+	for i := 0; i < BloomFilterHashes; i++ {
+		pos := (h1 + uint64(i)*h2) % BloomFilterBits
+		if b.bits[pos/8]&(1<<(pos%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives two independent hashes for double hashing (h1 + i*h2),
+// the standard way to synthesize k hash functions from two.
+func bloomHashes(key string) (uint64, uint64) {
+	h1 := fnv.New64a()
+	h1.Write([]byte(key))
+	sum1 := h1.Sum64()
+
+	h2 := fnv.New64()
+	h2.Write([]byte(key))
+	sum2 := h2.Sum64()
+
+	return sum1, sum2
+}
+
+func (b *bloomFilter) MarshalJSON() ([]byte, error) {
+	encoded := base64.StdEncoding.EncodeToString(b.bits)
+	return json.Marshal(encoded)
+}
+
+func (b *bloomFilter) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	b.bits = decoded
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBloomFilter_AddAndMightContain(t *testing.T) {
+	b := newBloomFilter()
+	if b.MightContain("a") {
+		t.Error("empty filter should not claim to contain anything")
+	}
+
+	b.Add("a")
+	if !b.MightContain("a") {
+		t.Error("filter should contain a key right after Add")
+	}
+	if b.MightContain("b") {
+		t.Error("filter should not claim an unadded key (low false-positive expected for a single entry)")
+	}
+}
+
+func TestBloomFilter_NoFalseNegatives(t *testing.T) {
+	b := newBloomFilter()
+	keys := make([]string, 0, 500)
+	for i := 0; i < 500; i++ {
+		keys = append(keys, hashArticle("https://example.com/a", string(rune(i))))
+	}
+	for _, k := range keys {
+		b.Add(k)
+	}
+	for _, k := range keys {
+		if !b.MightContain(k) {
+			t.Fatalf("false negative for key %q", k)
+		}
+	}
+}
+
+func TestBloomFilter_JSONRoundTrip(t *testing.T) {
+	b := newBloomFilter()
+	b.Add("a")
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var reloaded bloomFilter
+	if err := json.Unmarshal(data, &reloaded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reloaded.MightContain("a") {
+		t.Error("key added before marshaling should still be found after unmarshaling")
+	}
+}
@@ -0,0 +1,304 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	pinTTL      = 10 * time.Minute
+	pollTimeout = 30 * time.Second
+)
+
+// telegramAPIBase is a var, not a const, so tests can point it at an
+// httptest.Server instead of the real Telegram API.
+var telegramAPIBase = "https://api.telegram.org/bot%s"
+
+// pendingBind is a subscription awaiting PIN confirmation, keyed by PIN.
+type pendingBind struct {
+	ChatID  int64
+	FeedURL string
+	Expires time.Time
+}
+
+// Bot is a long-polling Telegram bot that dispatches /subscribe, /unsubscribe,
+// /list, /pause, /resume, and /lang commands against a Store.
+type Bot struct {
+	token      string
+	httpClient *http.Client
+	store      *Store
+	admins     map[int64]bool
+	knownFeeds map[string]bool
+	logger     *slog.Logger
+
+	pending map[string]pendingBind
+}
+
+// New builds a Bot. admins are chat IDs authorized to run admin-only commands.
+// knownFeeds are the feed URLs configured in feeds.yaml; /subscribe rejects
+// any URL outside that set, since no worker ever polls it.
+func New(token string, store *Store, admins []int64, knownFeeds []string, logger *slog.Logger) *Bot {
+	adminSet := make(map[int64]bool, len(admins))
+	for _, id := range admins {
+		adminSet[id] = true
+	}
+	feedSet := make(map[string]bool, len(knownFeeds))
+	for _, u := range knownFeeds {
+		feedSet[u] = true
+	}
+	return &Bot{
+		token:      token,
+		httpClient: &http.Client{Timeout: pollTimeout + 10*time.Second},
+		store:      store,
+		admins:     adminSet,
+		knownFeeds: feedSet,
+		logger:     logger,
+		pending:    make(map[string]pendingBind),
+	}
+}
+
+type update struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+type getUpdatesResponse struct {
+	OK     bool     `json:"ok"`
+	Result []update `json:"result"`
+}
+
+// Run long-polls getUpdates and dispatches each message until ctx is cancelled.
+func (b *Bot) Run(ctx context.Context) error {
+	var offset int64
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		updates, err := b.getUpdates(ctx, offset)
+		if err != nil {
+			b.logger.Error("Error polling Telegram updates", "err", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message == nil || u.Message.Text == "" {
+				continue
+			}
+			b.handleMessage(u.Message.Chat.ID, strings.TrimSpace(u.Message.Text))
+		}
+	}
+}
+
+func (b *Bot) getUpdates(ctx context.Context, offset int64) ([]update, error) {
+	url := fmt.Sprintf(telegramAPIBase+"/getUpdates?timeout=%d&offset=%d", b.token, int(pollTimeout.Seconds()), offset)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	var parsed getUpdatesResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("JSON decode error: %w, body: %s", err, body)
+	}
+	if !parsed.OK {
+		return nil, fmt.Errorf("getUpdates returned not-ok: %s", body)
+	}
+	return parsed.Result, nil
+}
+
+func (b *Bot) handleMessage(chatID int64, text string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return
+	}
+
+	cmd := strings.ToLower(fields[0])
+	args := fields[1:]
+
+	switch {
+	case cmd == "/subscribe" && len(args) == 1:
+		b.handleSubscribe(chatID, args[0])
+	case cmd == "/unsubscribe" && len(args) == 1:
+		b.handleUnsubscribe(chatID, args[0])
+	case cmd == "/list":
+		b.handleList(chatID)
+	case cmd == "/pause":
+		b.handlePauseResume(chatID, true)
+	case cmd == "/resume":
+		b.handlePauseResume(chatID, false)
+	case cmd == "/lang" && len(args) == 1:
+		b.handleLang(chatID, args[0])
+	case cmd == "/broadcast" && len(args) >= 1:
+		b.handleBroadcast(chatID, strings.Join(args, " "))
+	case isPIN(text):
+		b.handlePINConfirm(chatID, text)
+	default:
+		b.reply(chatID, "Unknown command. Try /subscribe <url>, /unsubscribe <url>, /list, /pause, /resume, /lang ru|en.")
+	}
+}
+
+// handleBroadcast sends text to every subscribed chat. Admin-only: it lets
+// an operator notify subscribers directly (e.g. about an upcoming feed
+// outage) without needing per-chat access.
+func (b *Bot) handleBroadcast(chatID int64, text string) {
+	if !b.isAdmin(chatID) {
+		b.reply(chatID, "This command is admin-only.")
+		return
+	}
+
+	for _, target := range b.store.AllChatIDs() {
+		b.reply(target, text)
+	}
+}
+
+func (b *Bot) handleSubscribe(chatID int64, feedURL string) {
+	if !b.knownFeeds[feedURL] {
+		b.reply(chatID, fmt.Sprintf("%s is not a configured feed. Ask an admin to add it to feeds.yaml first.", feedURL))
+		return
+	}
+
+	pin, err := generatePIN()
+	if err != nil {
+		b.logger.Error("Failed to generate PIN", "err", err)
+		b.reply(chatID, "Internal error, please try again.")
+		return
+	}
+
+	b.pending[pin] = pendingBind{ChatID: chatID, FeedURL: feedURL, Expires: time.Now().Add(pinTTL)}
+	b.reply(chatID, fmt.Sprintf("To confirm subscribing to %s, reply with PIN %s within 10 minutes.", feedURL, pin))
+}
+
+func (b *Bot) handlePINConfirm(chatID int64, pin string) {
+	binding, ok := b.pending[pin]
+	if !ok || binding.ChatID != chatID || time.Now().After(binding.Expires) {
+		b.reply(chatID, "That PIN is invalid or has expired.")
+		return
+	}
+	delete(b.pending, pin)
+
+	if err := b.store.Subscribe(chatID, binding.FeedURL); err != nil {
+		b.logger.Error("Failed to persist subscription", "err", err, "chat_id", chatID)
+		b.reply(chatID, "Internal error, please try again.")
+		return
+	}
+	b.reply(chatID, fmt.Sprintf("Subscribed to %s.", binding.FeedURL))
+}
+
+func (b *Bot) handleUnsubscribe(chatID int64, feedURL string) {
+	if err := b.store.Unsubscribe(chatID, feedURL); err != nil {
+		b.logger.Error("Failed to unsubscribe", "err", err, "chat_id", chatID)
+		b.reply(chatID, "Internal error, please try again.")
+		return
+	}
+	b.reply(chatID, fmt.Sprintf("Unsubscribed from %s.", feedURL))
+}
+
+func (b *Bot) handleList(chatID int64) {
+	sub := b.store.Get(chatID)
+	if sub == nil || len(sub.FeedURLs) == 0 {
+		b.reply(chatID, "You have no active subscriptions.")
+		return
+	}
+	b.reply(chatID, "Your subscriptions:\n"+strings.Join(sub.FeedURLs, "\n"))
+}
+
+func (b *Bot) handlePauseResume(chatID int64, paused bool) {
+	if err := b.store.SetPaused(chatID, paused); err != nil {
+		b.reply(chatID, "You have no active subscriptions to pause or resume.")
+		return
+	}
+	if paused {
+		b.reply(chatID, "Paused. Send /resume to start receiving articles again.")
+	} else {
+		b.reply(chatID, "Resumed.")
+	}
+}
+
+func (b *Bot) handleLang(chatID int64, lang string) {
+	lang = strings.ToLower(lang)
+	if lang != "ru" && lang != "en" {
+		b.reply(chatID, "Supported languages: ru, en.")
+		return
+	}
+	if err := b.store.SetLang(chatID, lang); err != nil {
+		b.reply(chatID, "Subscribe to a feed first with /subscribe <url>.")
+		return
+	}
+	b.reply(chatID, fmt.Sprintf("Language set to %s.", lang))
+}
+
+// isAdmin reports whether chatID is authorized to run admin-only commands.
+func (b *Bot) isAdmin(chatID int64) bool {
+	return b.admins[chatID]
+}
+
+func (b *Bot) reply(chatID int64, text string) {
+	payload := map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		b.logger.Error("Failed to marshal reply", "err", err)
+		return
+	}
+
+	apiURL := fmt.Sprintf(telegramAPIBase+"/sendMessage", b.token)
+	resp, err := b.httpClient.Post(apiURL, "application/json", bytes.NewReader(jsonData))
+	if err != nil {
+		b.logger.Error("Failed to send reply", "err", err, "chat_id", chatID)
+		return
+	}
+	defer resp.Body.Close()
+}
+
+func isPIN(text string) bool {
+	if len(text) != 6 {
+		return false
+	}
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func generatePIN() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("error generating PIN: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
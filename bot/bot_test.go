@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newTestBot wires up a Bot against a fake Telegram API, capturing every
+// sendMessage call's text keyed by chat ID.
+func newTestBot(t *testing.T, knownFeeds []string, admins []int64) (*Bot, *sentMessages) {
+	t.Helper()
+
+	sent := &sentMessages{byChat: make(map[int64][]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.record(r)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	old := telegramAPIBase
+	telegramAPIBase = server.URL + "/bot%s"
+	t.Cleanup(func() { telegramAPIBase = old })
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	return New("token", store, admins, knownFeeds, testLogger()), sent
+}
+
+type sentMessages struct {
+	mu     sync.Mutex
+	byChat map[int64][]string
+}
+
+func (s *sentMessages) record(r *http.Request) {
+	var payload struct {
+		ChatID int64  `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byChat[payload.ChatID] = append(s.byChat[payload.ChatID], payload.Text)
+}
+
+func (s *sentMessages) last(chatID int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msgs := s.byChat[chatID]
+	if len(msgs) == 0 {
+		return ""
+	}
+	return msgs[len(msgs)-1]
+}
+
+func TestHandleSubscribe_RejectsUnconfiguredFeed(t *testing.T) {
+	b, sent := newTestBot(t, []string{"https://example.com/feed.xml"}, nil)
+
+	b.handleMessage(1, "/subscribe https://not-configured.example.com/feed.xml")
+
+	if got := sent.last(1); strings.Contains(got, "reply with PIN") {
+		t.Errorf("an unconfigured feed should not start the PIN flow, got reply: %q", got)
+	}
+	if len(b.pending) != 0 {
+		t.Error("no PIN should be pending for an unconfigured feed")
+	}
+	if sub := b.store.Get(1); sub != nil {
+		t.Error("no subscription should be persisted for an unconfigured feed")
+	}
+}
+
+func TestHandleSubscribe_PINConfirmFlow(t *testing.T) {
+	const feedURL = "https://example.com/feed.xml"
+	b, _ := newTestBot(t, []string{feedURL}, nil)
+
+	b.handleMessage(1, "/subscribe "+feedURL)
+
+	if len(b.pending) != 1 {
+		t.Fatalf("expected one pending PIN bind, got %d", len(b.pending))
+	}
+	var pin string
+	for p := range b.pending {
+		pin = p
+	}
+
+	b.handleMessage(1, pin)
+
+	sub := b.store.Get(1)
+	if sub == nil || len(sub.FeedURLs) != 1 || sub.FeedURLs[0] != feedURL {
+		t.Fatalf("expected chat 1 to be subscribed to %s, got %+v", feedURL, sub)
+	}
+	if len(b.pending) != 0 {
+		t.Error("PIN should be consumed after confirmation")
+	}
+}
+
+func TestHandlePINConfirm_WrongChatRejected(t *testing.T) {
+	const feedURL = "https://example.com/feed.xml"
+	b, _ := newTestBot(t, []string{feedURL}, nil)
+
+	b.handleMessage(1, "/subscribe "+feedURL)
+	var pin string
+	for p := range b.pending {
+		pin = p
+	}
+
+	b.handleMessage(2, pin)
+
+	if sub := b.store.Get(2); sub != nil {
+		t.Error("a different chat confirming the PIN should not bind it")
+	}
+}
+
+func TestHandleBroadcast_AdminOnly(t *testing.T) {
+	const feedURL = "https://example.com/feed.xml"
+	b, sent := newTestBot(t, []string{feedURL}, []int64{99})
+
+	b.handleMessage(1, "/broadcast hello everyone")
+	if got := sent.last(1); got != "This command is admin-only." {
+		t.Errorf("non-admin broadcast should be rejected, got %q", got)
+	}
+
+	b.handleMessage(99, "/broadcast hello everyone")
+	if got := sent.last(99); got == "This command is admin-only." {
+		t.Error("admin broadcast should not be rejected")
+	}
+}
+
+func TestIsPIN(t *testing.T) {
+	cases := map[string]bool{
+		"123456": true,
+		"12345":  false,
+		"abcdef": false,
+		"":       false,
+	}
+	for text, want := range cases {
+		if got := isPIN(text); got != want {
+			t.Errorf("isPIN(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package bot implements an interactive Telegram bot that lets users manage
+// their own feed subscriptions at runtime, on top of the statically
+// configured feeds in feeds.yaml.
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Subscription is one chat's feed set, managed entirely through bot commands.
+type Subscription struct {
+	ChatID   int64    `json:"chat_id"`
+	FeedURLs []string `json:"feed_urls"`
+	Lang     string   `json:"lang"`
+	Paused   bool     `json:"paused"`
+}
+
+// Store persists subscriptions to a JSON file, guarded by a mutex since
+// updates arrive concurrently from the bot's command dispatcher.
+type Store struct {
+	path string
+	mu   sync.Mutex
+	subs map[int64]*Subscription
+}
+
+// NewStore loads subscriptions from path, or starts empty if it doesn't exist yet.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path, subs: make(map[int64]*Subscription)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("read error: %w", err)
+	}
+
+	var list []*Subscription
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("JSON decode error: %w", err)
+	}
+	for _, sub := range list {
+		s.subs[sub.ChatID] = sub
+	}
+	return s, nil
+}
+
+func (s *Store) saveLocked() error {
+	list := make([]*Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		list = append(list, sub)
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("serialization error: %w", err)
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Get returns the subscription for chatID, or nil if it doesn't exist.
+func (s *Store) Get(chatID int64) *Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subs[chatID]
+}
+
+// Subscribe adds feedURL to chatID's feed set, binding the chat if needed.
+func (s *Store) Subscribe(chatID int64, feedURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[chatID]
+	if !ok {
+		sub = &Subscription{ChatID: chatID, Lang: "ru"}
+		s.subs[chatID] = sub
+	}
+	for _, u := range sub.FeedURLs {
+		if u == feedURL {
+			return nil
+		}
+	}
+	sub.FeedURLs = append(sub.FeedURLs, feedURL)
+	return s.saveLocked()
+}
+
+// Unsubscribe removes feedURL from chatID's feed set.
+func (s *Store) Unsubscribe(chatID int64, feedURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[chatID]
+	if !ok {
+		return nil
+	}
+	kept := sub.FeedURLs[:0]
+	for _, u := range sub.FeedURLs {
+		if u != feedURL {
+			kept = append(kept, u)
+		}
+	}
+	sub.FeedURLs = kept
+	return s.saveLocked()
+}
+
+// SetPaused pauses or resumes delivery for chatID.
+func (s *Store) SetPaused(chatID int64, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[chatID]
+	if !ok {
+		return fmt.Errorf("chat %d is not subscribed", chatID)
+	}
+	sub.Paused = paused
+	return s.saveLocked()
+}
+
+// SetLang sets chatID's preferred translation language.
+func (s *Store) SetLang(chatID int64, lang string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[chatID]
+	if !ok {
+		return fmt.Errorf("chat %d is not subscribed", chatID)
+	}
+	sub.Lang = lang
+	return s.saveLocked()
+}
+
+// LangFor returns chatID's preferred translation language, or "" if the chat
+// is unknown.
+func (s *Store) LangFor(chatID int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[chatID]
+	if !ok {
+		return ""
+	}
+	return sub.Lang
+}
+
+// ChatsForFeed returns the chat IDs subscribed and not paused for feedURL,
+// so the monitor can fan out deliveries beyond a feed's static chat_id.
+func (s *Store) ChatsForFeed(feedURL string) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var chats []int64
+	for _, sub := range s.subs {
+		if sub.Paused {
+			continue
+		}
+		for _, u := range sub.FeedURLs {
+			if u == feedURL {
+				chats = append(chats, sub.ChatID)
+				break
+			}
+		}
+	}
+	return chats
+}
+
+// AllChatIDs returns every subscribed chat ID, used by admin-only broadcasts.
+func (s *Store) AllChatIDs() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chats := make([]int64, 0, len(s.subs))
+	for chatID := range s.subs {
+		chats = append(chats, chatID)
+	}
+	return chats
+}
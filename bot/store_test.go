@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_SubscribeAndChatsForFeed(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	if err := store.Subscribe(1, "https://example.com/feed.xml"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if err := store.Subscribe(1, "https://example.com/feed.xml"); err != nil {
+		t.Fatalf("Subscribe (duplicate): %v", err)
+	}
+	if sub := store.Get(1); len(sub.FeedURLs) != 1 {
+		t.Fatalf("duplicate Subscribe should not add a second entry, got %+v", sub.FeedURLs)
+	}
+
+	chats := store.ChatsForFeed("https://example.com/feed.xml")
+	if len(chats) != 1 || chats[0] != 1 {
+		t.Fatalf("ChatsForFeed = %v, want [1]", chats)
+	}
+
+	if err := store.SetPaused(1, true); err != nil {
+		t.Fatalf("SetPaused: %v", err)
+	}
+	if chats := store.ChatsForFeed("https://example.com/feed.xml"); len(chats) != 0 {
+		t.Errorf("paused chat should be excluded from ChatsForFeed, got %v", chats)
+	}
+}
+
+func TestStore_Unsubscribe(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.Subscribe(1, "https://example.com/feed.xml")
+
+	if err := store.Unsubscribe(1, "https://example.com/feed.xml"); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+	if chats := store.ChatsForFeed("https://example.com/feed.xml"); len(chats) != 0 {
+		t.Errorf("expected no chats after Unsubscribe, got %v", chats)
+	}
+}
+
+func TestStore_SetPausedRequiresExistingSubscription(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := store.SetPaused(1, true); err == nil {
+		t.Error("SetPaused on an unknown chat should error")
+	}
+}
+
+func TestStore_PersistsAcrossReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subscriptions.json")
+
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.Subscribe(1, "https://example.com/feed.xml")
+	store.SetLang(1, "en")
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore (reload): %v", err)
+	}
+	if lang := reloaded.LangFor(1); lang != "en" {
+		t.Errorf("LangFor after reload = %q, want %q", lang, "en")
+	}
+	if chats := reloaded.ChatsForFeed("https://example.com/feed.xml"); len(chats) != 1 {
+		t.Errorf("subscription should survive reload, got %v", chats)
+	}
+}
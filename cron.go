@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is a parsed, expanded set of valid values for one field of a cron expression.
+type cronField map[int]bool
+
+// nextCronRun computes the next time after `after` that matches the standard
+// 5-field cron expression "minute hour day-of-month month day-of-week".
+// Supports `*`, `*/N` steps, comma lists, and dash ranges.
+func nextCronRun(expr string, after time.Time) (time.Time, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return time.Time{}, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid minute field: %w", err)
+	}
+	hours, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid hour field: %w", err)
+	}
+	doms, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	months, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month field: %w", err)
+	}
+	dows, err := parseCronField(fields[4], 0, 6)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, 1, 0)
+			continue
+		}
+		if !dayMatches(fields[2], fields[4], doms, dows, t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, 1)
+			continue
+		}
+		if !hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(time.Hour)
+			continue
+		}
+		if !minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("cron expression %q does not match any time within 5 years", expr)
+}
+
+// dayMatches reports whether t's day satisfies the day-of-month and
+// day-of-week fields, following standard cron semantics: if both fields are
+// restricted (not "*"), a day matching either one is enough; if only one is
+// restricted, that field alone decides.
+func dayMatches(domField, dowField string, doms, dows cronField, t time.Time) bool {
+	domRestricted := domField != "*"
+	dowRestricted := dowField != "*"
+	switch {
+	case domRestricted && dowRestricted:
+		return doms[t.Day()] || dows[int(t.Weekday())]
+	case domRestricted:
+		return doms[t.Day()]
+	case dowRestricted:
+		return dows[int(t.Weekday())]
+	default:
+		return true
+	}
+}
+
+func parseCronField(field string, min, max int) (cronField, error) {
+	result := make(cronField)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		rangePart := part
+		if idx := strings.Index(part, "/"); idx != -1 {
+			s, err := strconv.Atoi(part[idx+1:])
+			if err != nil || s <= 0 {
+				return nil, fmt.Errorf("invalid step %q", part)
+			}
+			step = s
+			rangePart = part[:idx]
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			if idx := strings.Index(rangePart, "-"); idx != -1 {
+				l, err1 := strconv.Atoi(rangePart[:idx])
+				h, err2 := strconv.Atoi(rangePart[idx+1:])
+				if err1 != nil || err2 != nil {
+					return nil, fmt.Errorf("invalid range %q", rangePart)
+				}
+				lo, hi = l, h
+			} else {
+				v, err := strconv.Atoi(rangePart)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q", rangePart)
+				}
+				lo, hi = v, v
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value %q out of range [%d,%d]", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			result[v] = true
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextCronRun(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) // a Thursday
+
+	cases := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			want: time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "step minutes",
+			expr: "*/15 * * * *",
+			want: time.Date(2026, 1, 1, 0, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "comma list of hours",
+			expr: "0 9,21 * * *",
+			want: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "range of months",
+			expr: "0 0 1 6-8 *",
+			want: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := nextCronRun(c.expr, after)
+			if err != nil {
+				t.Fatalf("nextCronRun(%q): %v", c.expr, err)
+			}
+			if !got.Equal(c.want) {
+				t.Errorf("nextCronRun(%q) = %v, want %v", c.expr, got, c.want)
+			}
+		})
+	}
+}
+
+// Standard cron semantics: when BOTH day-of-month and day-of-week are
+// restricted, a day matching either field is due; this is an OR, not an AND.
+func TestNextCronRun_DayOfMonthOrDayOfWeek(t *testing.T) {
+	after := time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC) // a Saturday, after the 1st has passed
+
+	// "1st of the month OR Monday" at 09:00 should land on the next Monday
+	// (2027-01-04), not wait a month for a day that is both the 1st and a Monday.
+	got, err := nextCronRun("0 9 1 * 1", after)
+	if err != nil {
+		t.Fatalf("nextCronRun: %v", err)
+	}
+	want := time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("nextCronRun(\"0 9 1 * 1\") = %v, want %v", got, want)
+	}
+}
+
+func TestNextCronRun_InvalidExpr(t *testing.T) {
+	if _, err := nextCronRun("* * *", time.Now()); err == nil {
+		t.Error("expected error for wrong field count")
+	}
+	if _, err := nextCronRun("60 * * * *", time.Now()); err == nil {
+		t.Error("expected error for out-of-range minute")
+	}
+}
+
+func TestParseCronField(t *testing.T) {
+	f, err := parseCronField("1-3,10", 0, 59)
+	if err != nil {
+		t.Fatalf("parseCronField: %v", err)
+	}
+	for _, v := range []int{1, 2, 3, 10} {
+		if !f[v] {
+			t.Errorf("expected %d to be set", v)
+		}
+	}
+	if f[4] || f[9] {
+		t.Errorf("unexpected values set: %v", f)
+	}
+
+	if _, err := parseCronField("5-2", 0, 59); err == nil {
+		t.Error("expected error for inverted range")
+	}
+	if _, err := parseCronField("*/0", 0, 59); err == nil {
+		t.Error("expected error for zero step")
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const HTMLCacheDir = "cache/html"
+
+var (
+	positiveClassRe = regexp.MustCompile(`(?i)article|content|post`)
+	negativeClassRe = regexp.MustCompile(`(?i)comment|sidebar|footer|nav`)
+	whitespaceRe    = regexp.MustCompile(`\s+`)
+)
+
+// extractMainContent fetches pageURL (using a local cache keyed by URL hash
+// to avoid re-downloading on retries) and runs a readability-style
+// extractor over the HTML to produce clean paragraph text.
+func extractMainContent(ctx context.Context, pageURL string) (string, error) {
+	body, err := fetchCachedHTML(ctx, pageURL)
+	if err != nil {
+		return "", fmt.Errorf("error fetching page: %w", err)
+	}
+
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("error parsing HTML: %w", err)
+	}
+
+	stripTags(doc, "script", "style")
+
+	best := bestCandidate(doc)
+	if best == nil {
+		return "", errors.New("no content candidate found")
+	}
+
+	return collapseWhitespace(nodeText(best)), nil
+}
+
+func fetchCachedHTML(ctx context.Context, pageURL string) (string, error) {
+	sum := sha256.Sum256([]byte(pageURL))
+	cachePath := filepath.Join(HTMLCacheDir, hex.EncodeToString(sum[:])+".html")
+
+	if cached, err := os.ReadFile(cachePath); err == nil {
+		return string(cached), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ZeroHedgeMonitor/1.0)")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error fetching page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("page returned status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading page body: %w", err)
+	}
+
+	if err := os.MkdirAll(HTMLCacheDir, 0755); err == nil {
+		_ = os.WriteFile(cachePath, body, 0644)
+	}
+
+	return string(body), nil
+}
+
+// bestCandidate scores every div/article/section by text-to-link-density
+// and class/id naming, returning the highest-scoring node.
+func bestCandidate(doc *html.Node) *html.Node {
+	var best *html.Node
+	var bestScore float64
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && (n.Data == "div" || n.Data == "article" || n.Data == "section") {
+			if score := candidateScore(n); score > bestScore {
+				bestScore = score
+				best = n
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return best
+}
+
+func candidateScore(n *html.Node) float64 {
+	text := nodeText(n)
+	textLen := float64(len(text))
+	if textLen == 0 {
+		return 0
+	}
+
+	linkLen := float64(len(linkText(n)))
+	density := linkLen / textLen
+	score := textLen * (1 - density)
+
+	class := attr(n, "class") + " " + attr(n, "id")
+	if negativeClassRe.MatchString(class) {
+		return 0
+	}
+	if positiveClassRe.MatchString(class) {
+		score *= 1.5
+	}
+
+	return score
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+func linkText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			sb.WriteString(nodeText(n))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// stripTags removes every element with one of the given tag names from the tree.
+func stripTags(n *html.Node, tags ...string) {
+	tagSet := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		tagSet[t] = true
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			if c.Type == html.ElementNode && tagSet[c.Data] {
+				n.RemoveChild(c)
+			} else {
+				walk(c)
+			}
+			c = next
+		}
+	}
+	walk(n)
+}
+
+func collapseWhitespace(text string) string {
+	return strings.TrimSpace(whitespaceRe.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const testArticleHTML = `<html><body>
+<nav class="nav"><a href="/a">A</a><a href="/b">B</a><a href="/c">C</a></nav>
+<div class="article-content">
+<p>This is the real article body with plenty of unlinked prose that should
+score far higher than the navigation links or the sidebar clutter around it,
+since it has a low link-to-text density and a positive class name.</p>
+</div>
+<div class="sidebar"><a href="/x">related link one</a><a href="/y">related link two</a></div>
+</body></html>`
+
+func TestBestCandidate_PrefersLowLinkDensityContent(t *testing.T) {
+	doc := mustParseHTML(t, testArticleHTML)
+
+	best := bestCandidate(doc)
+	if best == nil {
+		t.Fatal("expected a candidate node")
+	}
+	text := collapseWhitespace(nodeText(best))
+	if !strings.Contains(text, "real article body") {
+		t.Errorf("expected the article div to win, got text: %q", text)
+	}
+}
+
+func TestCandidateScore_NegativeClassIsZero(t *testing.T) {
+	doc := mustParseHTML(t, `<div class="comment-section"><p>some comment text here that is fairly long to avoid a zero-length short circuit</p></div>`)
+	div := firstElement(doc, "div")
+	if score := candidateScore(div); score != 0 {
+		t.Errorf("candidateScore for a negative-class node = %v, want 0", score)
+	}
+}
+
+func TestCandidateScore_PositiveClassBoosts(t *testing.T) {
+	plain := mustParseHTML(t, `<div class="wrapper"><p>some unlinked prose that is identical in both cases for a fair comparison</p></div>`)
+	positive := mustParseHTML(t, `<div class="post-content"><p>some unlinked prose that is identical in both cases for a fair comparison</p></div>`)
+
+	plainScore := candidateScore(firstElement(plain, "div"))
+	positiveScore := candidateScore(firstElement(positive, "div"))
+
+	if positiveScore <= plainScore {
+		t.Errorf("positive class score %v should exceed plain score %v", positiveScore, plainScore)
+	}
+}
+
+func TestExtractMainContent_CachesByURLHash(t *testing.T) {
+	withTempCacheDir(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testArticleHTML))
+	}))
+	defer server.Close()
+
+	first, err := extractMainContent(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("extractMainContent: %v", err)
+	}
+	second, err := extractMainContent(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("extractMainContent (cached): %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected a single HTTP fetch due to caching, got %d", calls)
+	}
+	if first != second {
+		t.Errorf("cached extraction should be identical, got %q vs %q", first, second)
+	}
+}
+
+func TestExtractMainContent_NoCandidateErrors(t *testing.T) {
+	withTempCacheDir(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<html><body><span>too small</span></body></html>`))
+	}))
+	defer server.Close()
+
+	if _, err := extractMainContent(context.Background(), server.URL); err == nil {
+		t.Error("expected an error when no div/article/section candidate exists")
+	}
+}
+
+func mustParseHTML(t *testing.T, s string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("parsing test HTML: %v", err)
+	}
+	return doc
+}
+
+// firstElement returns the first element in doc with the given tag name.
+func firstElement(doc *html.Node, tag string) *html.Node {
+	var found *html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if found != nil {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == tag {
+			found = n
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return found
+}
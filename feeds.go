@@ -0,0 +1,401 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FeedsConfigFile = "feeds.yaml"
+	StateDir        = "state"
+)
+
+// FeedConfig describes a single feed subscription loaded from feeds.yaml/feeds.json.
+type FeedConfig struct {
+	Name        string   `yaml:"name" json:"name"`
+	URLs        []string `yaml:"urls" json:"urls"`
+	Cron        string   `yaml:"cron" json:"cron"`
+	Proxy       string   `yaml:"proxy" json:"proxy"`
+	ChatID      string   `yaml:"chat_id" json:"chat_id"`
+	SourceLang  string   `yaml:"source_lang" json:"source_lang"`
+	Enabled     bool     `yaml:"enabled" json:"enabled"`
+	FullContent bool     `yaml:"full_content" json:"full_content"`
+}
+
+// FeedItem is the normalized representation of an article, regardless of the
+// underlying feed format (RSS 2.0, Atom, or JSON Feed).
+type FeedItem struct {
+	Title       string
+	Link        string
+	Description string
+	PubDate     string
+}
+
+// jsonFeed models the subset of https://www.jsonfeed.org/version/1.1/ we consume.
+type jsonFeed struct {
+	Items []struct {
+		Title         string `json:"title"`
+		URL           string `json:"url"`
+		ContentHTML   string `json:"content_html"`
+		DatePublished string `json:"date_published"`
+	} `json:"items"`
+}
+
+// atomFeed models the subset of RFC 4287 we consume.
+type atomFeed struct {
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+func (f atomFeed) items() []FeedItem {
+	items := make([]FeedItem, 0, len(f.Entries))
+	for _, e := range f.Entries {
+		link := ""
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		desc := e.Summary
+		if desc == "" {
+			desc = e.Content
+		}
+		pubDate := e.Published
+		if pubDate == "" {
+			pubDate = e.Updated
+		}
+		items = append(items, FeedItem{
+			Title:       e.Title,
+			Link:        link,
+			Description: desc,
+			PubDate:     pubDate,
+		})
+	}
+	return items
+}
+
+func (f jsonFeed) items() []FeedItem {
+	items := make([]FeedItem, 0, len(f.Items))
+	for _, it := range f.Items {
+		items = append(items, FeedItem{
+			Title:       it.Title,
+			Link:        it.URL,
+			Description: it.ContentHTML,
+			PubDate:     it.DatePublished,
+		})
+	}
+	return items
+}
+
+func (f RSS) items() []FeedItem {
+	items := make([]FeedItem, 0, len(f.Channel.Items))
+	for _, it := range f.Channel.Items {
+		items = append(items, FeedItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			Description: it.Description,
+			PubDate:     it.PubDate,
+		})
+	}
+	return items
+}
+
+// loadFeedsConfig reads feed descriptors from a YAML or JSON file, detected by extension.
+func loadFeedsConfig(path string) ([]FeedConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading feeds config: %w", err)
+	}
+
+	var feeds []FeedConfig
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &feeds); err != nil {
+			return nil, fmt.Errorf("error decoding feeds config as JSON: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &feeds); err != nil {
+			return nil, fmt.Errorf("error decoding feeds config as YAML: %w", err)
+		}
+	}
+
+	if len(feeds) == 0 {
+		return nil, fmt.Errorf("feeds config %q defines no feeds", path)
+	}
+
+	return feeds, nil
+}
+
+// fetchFeed downloads a feed, failing over across feed.URLs, and auto-detects
+// the wire format (RSS 2.0, Atom, or JSON Feed) from the response body.
+func fetchFeed(ctx context.Context, feed FeedConfig) ([]FeedItem, error) {
+	var lastErr error
+	for _, feedURL := range feed.URLs {
+		items, err := fetchFeedURL(ctx, feedURL, feed.Proxy)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("all feed URLs failed for %q: %w", feed.Name, lastErr)
+}
+
+// parseFeedBody sniffs the body and parses it as JSON Feed, Atom, or RSS 2.0.
+func parseFeedBody(body []byte) ([]FeedItem, error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "{") {
+		var jf jsonFeed
+		if err := json.Unmarshal(body, &jf); err != nil {
+			return nil, fmt.Errorf("error decoding JSON feed: %w", err)
+		}
+		return jf.items(), nil
+	}
+
+	// Peek at the root element to distinguish Atom from RSS 2.0.
+	decoder := xml.NewDecoder(strings.NewReader(trimmed))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error probing feed XML: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local == "feed" {
+			var af atomFeed
+			if err := xml.Unmarshal(body, &af); err != nil {
+				return nil, fmt.Errorf("error decoding Atom feed: %w", err)
+			}
+			return af.items(), nil
+		}
+		break
+	}
+
+	var rss RSS
+	if err := xml.Unmarshal(body, &rss); err != nil {
+		return nil, fmt.Errorf("error decoding RSS feed: %w", err)
+	}
+	return rss.items(), nil
+}
+
+// DefaultRecipientLang is the translation target for a feed's statically
+// configured chat_id, which predates the bot's per-chat /lang command.
+const DefaultRecipientLang = "ru"
+
+// recipient is a delivery target paired with its preferred translation
+// language, so processFeedArticles can translate once per distinct language
+// rather than once per feed.
+type recipient struct {
+	ChatID string
+	Lang   string
+}
+
+// recipientsForFeed returns the feed's static chat_id (if any) plus every
+// chat that subscribed to one of feed.URLs through the interactive bot, each
+// paired with its preferred language.
+func recipientsForFeed(feed FeedConfig) []recipient {
+	chats := make(map[string]string)
+	if feed.ChatID != "" {
+		chats[feed.ChatID] = DefaultRecipientLang
+	}
+
+	if subscriptionStore != nil {
+		for _, feedURL := range feed.URLs {
+			for _, chatID := range subscriptionStore.ChatsForFeed(feedURL) {
+				lang := subscriptionStore.LangFor(chatID)
+				if lang == "" {
+					lang = DefaultRecipientLang
+				}
+				chats[strconv.FormatInt(chatID, 10)] = lang
+			}
+		}
+	}
+
+	recipients := make([]recipient, 0, len(chats))
+	for chatID, lang := range chats {
+		recipients = append(recipients, recipient{ChatID: chatID, Lang: lang})
+	}
+	return recipients
+}
+
+// feedURLs flattens every URL across feeds, so the bot can reject /subscribe
+// requests for URLs no worker actually polls.
+func feedURLs(feeds []FeedConfig) []string {
+	var urls []string
+	for _, feed := range feeds {
+		if !feed.Enabled {
+			continue
+		}
+		urls = append(urls, feed.URLs...)
+	}
+	return urls
+}
+
+// runFeedWorker schedules processFeedArticles according to feed.Cron until ctx is cancelled.
+func runFeedWorker(ctx context.Context, logger *slog.Logger, feed FeedConfig) {
+	logger.Info("Starting feed worker", "feed", feed.Name, "cron", feed.Cron)
+
+	for {
+		next, err := nextCronRun(feed.Cron, time.Now().UTC())
+		if err != nil {
+			logger.Error("Invalid cron expression, stopping worker", "feed", feed.Name, "cron", feed.Cron, "err", err)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if err := processFeedArticles(ctx, logger, feed); err != nil {
+				logger.Error("Error processing feed", "feed", feed.Name, "err", err)
+			}
+		}
+	}
+}
+
+// processFeedArticles fetches, translates, and delivers unseen articles for a single feed.
+func processFeedArticles(ctx context.Context, logger *slog.Logger, feed FeedConfig) error {
+	fetchStart := time.Now()
+	items, err := fetchFeed(ctx, feed)
+	fetchDuration.WithLabelValues(feed.Name).Observe(time.Since(fetchStart).Seconds())
+	if err != nil {
+		fetchTotal.WithLabelValues(feed.Name, "failure").Inc()
+		return fmt.Errorf("error fetching feed %q: %w", feed.Name, err)
+	}
+	fetchTotal.WithLabelValues(feed.Name, "success").Inc()
+	feedHealth.markSuccess(feed.Name)
+
+	if len(items) == 0 {
+		queueDepth.WithLabelValues(feed.Name).Set(0)
+		logger.Warn("No articles found in feed", "feed", feed.Name)
+		return nil
+	}
+
+	state, err := loadFeedState(feed.Name)
+	if err != nil {
+		return fmt.Errorf("error loading state for feed %q: %w", feed.Name, err)
+	}
+
+	unsent := 0
+	for _, item := range items {
+		if !state.Seen(hashArticle(item.Link, item.PubDate)) {
+			unsent++
+		}
+	}
+	queueDepth.WithLabelValues(feed.Name).Set(float64(unsent))
+
+	newArticles := 0
+	for _, item := range items {
+		if newArticles >= MaxArticlesToSend {
+			logger.Debug("Reached maximum articles to send", "feed", feed.Name, "max", MaxArticlesToSend)
+			break
+		}
+
+		if !isValidURL(item.Link) {
+			logger.Error("Invalid URL in article", "feed", feed.Name, "url", item.Link)
+			continue
+		}
+
+		hash := hashArticle(item.Link, item.PubDate)
+		if state.Seen(hash) {
+			continue
+		}
+
+		content := cleanText(item.Description)
+		if content == "" {
+			content = cleanText(item.Title)
+			logger.Debug("Using title as content as description is empty", "feed", feed.Name, "title", item.Title)
+		}
+
+		if feed.FullContent {
+			if extracted, err := extractMainContent(ctx, item.Link); err != nil {
+				logger.Warn("Full-content extraction failed, falling back to description", "feed", feed.Name, "url", item.Link, "err", err)
+			} else if len(extracted) > len(content) {
+				content = extracted
+			}
+		}
+
+		if content == "" {
+			logger.Error("Empty content for article", "feed", feed.Name, "url", item.Link)
+			continue
+		}
+
+		recipients := recipientsForFeed(feed)
+		if len(recipients) == 0 {
+			logger.Warn("No recipients for feed, skipping article without marking it seen", "feed", feed.Name, "url", item.Link)
+			continue
+		}
+
+		time.Sleep(1 * time.Second)
+
+		messages := make(map[string]string, len(recipients))
+		sendSucceeded := false
+		for _, r := range recipients {
+			message, ok := messages[r.Lang]
+			if !ok {
+				summary, err := translateAndSummarize(ctx, logger, content, feed.SourceLang, r.Lang)
+				if err != nil {
+					logger.Error("Translation error", "feed", feed.Name, "lang", r.Lang, "err", err, "url", item.Link)
+					continue
+				}
+				message = fmt.Sprintf(
+					"<b>📌 %s</b>\n\n%s\n\n<b>📅 %s</b>\n🔗 <a href=\"%s\">Read full article</a>",
+					cleanText(item.Title),
+					summary,
+					cleanText(item.PubDate),
+					item.Link,
+				)
+				messages[r.Lang] = message
+			}
+
+			if err := sendToTelegram(ctx, r.ChatID, message); err != nil {
+				sendTotal.WithLabelValues(feed.Name, "failure").Inc()
+				logger.Error("Error sending to Telegram", "feed", feed.Name, "chat_id", r.ChatID, "err", err, "url", item.Link)
+				continue
+			}
+			sendTotal.WithLabelValues(feed.Name, "success").Inc()
+			sendSucceeded = true
+		}
+
+		// At least one recipient got the article: mark it seen so the next poll
+		// doesn't resend it to recipients who already received it. Recipients
+		// whose send failed just miss this article, same as a translation
+		// failure for a lone recipient — logged above for operators to notice.
+		if !sendSucceeded {
+			continue
+		}
+
+		state.Record(hash)
+		newArticles++
+	}
+
+	if err := saveFeedState(feed.Name, state); err != nil {
+		return fmt.Errorf("error saving state for feed %q: %w", feed.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/philyuchkoff/zerohedge/bot"
+)
+
+const testRSSItem = `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<item>
+<title>Test Article</title>
+<link>https://example.com/article-1</link>
+<description>Some article body.</description>
+<pubDate>2026-01-01</pubDate>
+</item>
+</channel></rss>`
+
+func TestRecipientsForFeed_StaticAndSubscribed(t *testing.T) {
+	store, err := bot.NewStore(t.TempDir() + "/subscriptions.json")
+	if err != nil {
+		t.Fatalf("bot.NewStore: %v", err)
+	}
+	store.Subscribe(111, "https://example.com/feed.xml")
+	store.SetLang(111, "en")
+	// Same chat as the static chat_id, subscribed via the bot: it must only
+	// show up once in the result, not twice.
+	store.Subscribe(222, "https://example.com/feed.xml")
+
+	old := subscriptionStore
+	subscriptionStore = store
+	defer func() { subscriptionStore = old }()
+
+	feed := FeedConfig{ChatID: "222", URLs: []string{"https://example.com/feed.xml"}}
+	recipients := recipientsForFeed(feed)
+
+	byChat := make(map[string]string, len(recipients))
+	for _, r := range recipients {
+		byChat[r.ChatID] = r.Lang
+	}
+
+	if len(recipients) != 2 {
+		t.Fatalf("recipientsForFeed = %+v, want 2 recipients (deduped)", recipients)
+	}
+	if byChat["111"] != "en" {
+		t.Errorf("chat 111 lang = %q, want %q", byChat["111"], "en")
+	}
+	if byChat["222"] != DefaultRecipientLang {
+		t.Errorf("statically configured chat_id should use DefaultRecipientLang, got %q", byChat["222"])
+	}
+}
+
+func TestRecipientsForFeed_NoSubscriptionStore(t *testing.T) {
+	old := subscriptionStore
+	subscriptionStore = nil
+	defer func() { subscriptionStore = old }()
+
+	feed := FeedConfig{ChatID: "42", URLs: []string{"https://example.com/feed.xml"}}
+	recipients := recipientsForFeed(feed)
+	if len(recipients) != 1 || recipients[0].ChatID != "42" {
+		t.Fatalf("recipientsForFeed = %+v, want just the static chat_id", recipients)
+	}
+}
+
+// testSender fakes the Telegram sendMessage endpoint, letting tests fail
+// delivery for specific chat IDs and record who actually received a message.
+type testSender struct {
+	mu       sync.Mutex
+	sentTo   map[string]int
+	failChat map[string]bool
+}
+
+func newTestSender(failChat ...string) *testSender {
+	fail := make(map[string]bool, len(failChat))
+	for _, c := range failChat {
+		fail[c] = true
+	}
+	return &testSender{sentTo: make(map[string]int), failChat: fail}
+}
+
+func (s *testSender) handler(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ChatID string `json:"chat_id"`
+	}
+	json.NewDecoder(r.Body).Decode(&payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failChat[payload.ChatID] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	s.sentTo[payload.ChatID]++
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *testSender) count(chatID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sentTo[chatID]
+}
+
+// withTestFeedEnv wires up a fake Telegram API and a single-backend stub
+// translator chain, isolating feed state under a temp directory.
+func withTestFeedEnv(t *testing.T, sender *testSender) context.Context {
+	t.Helper()
+	withTempStateDir(t)
+
+	server := httptest.NewServer(http.HandlerFunc(sender.handler))
+	t.Cleanup(server.Close)
+
+	oldAPI := TelegramBotAPI
+	TelegramBotAPI = server.URL + "/bot%s/sendMessage"
+	t.Cleanup(func() { TelegramBotAPI = oldAPI })
+
+	oldTranslators := translators
+	translators = []Translator{stubTranslator{name: "stub", result: "translated"}}
+	t.Cleanup(func() { translators = oldTranslators })
+
+	return context.WithValue(context.Background(), "logger", testLogger())
+}
+
+func newTestFeedServer(t *testing.T, body string) FeedConfig {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return FeedConfig{Name: "test-feed", URLs: []string{server.URL}, ChatID: "1"}
+}
+
+func TestProcessFeedArticles_DedupPersistsAcrossRuns(t *testing.T) {
+	sender := newTestSender()
+	ctx := withTestFeedEnv(t, sender)
+	feed := newTestFeedServer(t, testRSSItem)
+
+	if err := processFeedArticles(ctx, testLogger(), feed); err != nil {
+		t.Fatalf("processFeedArticles (first run): %v", err)
+	}
+	if sender.count("1") != 1 {
+		t.Fatalf("chat 1 send count after first run = %d, want 1", sender.count("1"))
+	}
+
+	if err := processFeedArticles(ctx, testLogger(), feed); err != nil {
+		t.Fatalf("processFeedArticles (second run): %v", err)
+	}
+	if sender.count("1") != 1 {
+		t.Errorf("chat 1 send count after second run = %d, want 1 (article already seen, not resent)", sender.count("1"))
+	}
+}
+
+func TestProcessFeedArticles_PartialRecipientFailureStillRecordsSeen(t *testing.T) {
+	sender := newTestSender("2")
+	ctx := withTestFeedEnv(t, sender)
+
+	store, err := bot.NewStore(t.TempDir() + "/subscriptions.json")
+	if err != nil {
+		t.Fatalf("bot.NewStore: %v", err)
+	}
+	feed := newTestFeedServer(t, testRSSItem)
+	store.Subscribe(2, feed.URLs[0])
+
+	old := subscriptionStore
+	subscriptionStore = store
+	defer func() { subscriptionStore = old }()
+
+	if err := processFeedArticles(ctx, testLogger(), feed); err != nil {
+		t.Fatalf("processFeedArticles: %v", err)
+	}
+
+	if sender.count("1") != 1 {
+		t.Errorf("chat 1 (static, healthy) send count = %d, want 1", sender.count("1"))
+	}
+	if sender.count("2") != 0 {
+		t.Errorf("chat 2 (failing) should never have received a successful send, got %d", sender.count("2"))
+	}
+
+	// A second poll must not resend to chat 1, even though chat 2's delivery
+	// failed: once any recipient succeeds, the article is marked seen.
+	if err := processFeedArticles(ctx, testLogger(), feed); err != nil {
+		t.Fatalf("processFeedArticles (second run): %v", err)
+	}
+	if sender.count("1") != 1 {
+		t.Errorf("chat 1 send count after second run = %d, want 1 (no duplicate resend)", sender.count("1"))
+	}
+}
+
+func TestProcessFeedArticles_AllRecipientsFailLeavesArticleUnseen(t *testing.T) {
+	sender := newTestSender("1")
+	ctx := withTestFeedEnv(t, sender)
+	feed := newTestFeedServer(t, testRSSItem)
+
+	if err := processFeedArticles(ctx, testLogger(), feed); err != nil {
+		t.Fatalf("processFeedArticles: %v", err)
+	}
+
+	state, err := loadFeedState(feed.Name)
+	if err != nil {
+		t.Fatalf("loadFeedState: %v", err)
+	}
+	hash := hashArticle("https://example.com/article-1", "2026-01-01")
+	if state.Seen(hash) {
+		t.Error("article should not be marked seen when every recipient's send failed")
+	}
+}
+
+func TestProcessFeedArticles_NoRecipientsSkipsArticle(t *testing.T) {
+	sender := newTestSender()
+	ctx := withTestFeedEnv(t, sender)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testRSSItem))
+	}))
+	defer server.Close()
+
+	feed := FeedConfig{Name: "no-recipients", URLs: []string{server.URL}}
+
+	if err := processFeedArticles(ctx, testLogger(), feed); err != nil {
+		t.Fatalf("processFeedArticles: %v", err)
+	}
+
+	state, err := loadFeedState(feed.Name)
+	if err != nil {
+		t.Fatalf("loadFeedState: %v", err)
+	}
+	hash := hashArticle("https://example.com/article-1", "2026-01-01")
+	if state.Seen(hash) {
+		t.Error("article without recipients should not be marked seen")
+	}
+	if sender.count("1") != 0 {
+		t.Error("no message should have been sent")
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const HTTPCacheDir = "cache/http"
+
+// feedCacheEntry holds the conditional-GET validators and last-known-good
+// body for a single feed URL, so unchanged feeds short-circuit on 304.
+type feedCacheEntry struct {
+	ETag         string    `json:"etag"`
+	LastModified string    `json:"last_modified"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	MaxAge       int       `json:"max_age_seconds"`
+	Body         []byte    `json:"body"`
+}
+
+func feedCachePath(feedURL string) string {
+	sum := sha256.Sum256([]byte(feedURL))
+	return filepath.Join(HTTPCacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func loadFeedCacheEntry(feedURL string) (*feedCacheEntry, error) {
+	data, err := os.ReadFile(feedCachePath(feedURL))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read error: %w", err)
+	}
+
+	var entry feedCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("JSON decode error: %w", err)
+	}
+	return &entry, nil
+}
+
+func saveFeedCacheEntry(feedURL string, entry feedCacheEntry) error {
+	if err := os.MkdirAll(HTTPCacheDir, 0755); err != nil {
+		return fmt.Errorf("error creating cache dir: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("serialization error: %w", err)
+	}
+
+	return os.WriteFile(feedCachePath(feedURL), data, 0644)
+}
+
+// fresh reports whether a cached entry is still within its Cache-Control max-age.
+func (e *feedCacheEntry) fresh() bool {
+	return e != nil && e.MaxAge > 0 && time.Since(e.FetchedAt) < time.Duration(e.MaxAge)*time.Second
+}
+
+// parseMaxAge extracts max-age from a Cache-Control header value.
+func parseMaxAge(cacheControl string) int {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if after, ok := strings.CutPrefix(directive, "max-age="); ok {
+			if seconds, err := strconv.Atoi(after); err == nil {
+				return seconds
+			}
+		}
+	}
+	return 0
+}
+
+// parseRetryAfter extracts a Retry-After header value, in either delta-seconds
+// or HTTP-date form, returning 0 if absent or unparseable.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
+// backoffDelay returns an exponential backoff delay with jitter for the given
+// retry attempt (0-indexed), building on RetryDelay/MaxRetries.
+func backoffDelay(attempt int) time.Duration {
+	base := RetryDelay * time.Duration(1<<attempt)
+	jitter := time.Duration(rand.Int63n(int64(RetryDelay)))
+	return base + jitter
+}
+
+// clientForProxy returns the shared httpClient, or a dedicated client routed
+// through proxyURL via http.ProxyURL if one is configured for the feed.
+func clientForProxy(proxyURL string) (*http.Client, error) {
+	if proxyURL == "" {
+		return httpClient, nil
+	}
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+	return &http.Client{
+		Timeout:   httpClient.Timeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(parsed)},
+	}, nil
+}
+
+// fetchFeedURL downloads feedURL with conditional GET (If-None-Match /
+// If-Modified-Since), short-circuiting on 304, and retries 429/5xx with
+// exponential backoff and jitter, honoring Retry-After when present. If
+// proxyURL is set, the request is routed through it.
+func fetchFeedURL(ctx context.Context, feedURL, proxyURL string) ([]FeedItem, error) {
+	client, err := clientForProxy(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := loadFeedCacheEntry(feedURL)
+	if err != nil {
+		cache = nil
+	}
+	if cache.fresh() {
+		return parseFeedBody(cache.Body)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= MaxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ZeroHedgeMonitor/1.0)")
+		if cache != nil {
+			if cache.ETag != "" {
+				req.Header.Set("If-None-Match", cache.ETag)
+			}
+			if cache.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cache.LastModified)
+			}
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("error fetching feed: %w", err)
+			time.Sleep(backoffDelay(attempt))
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified:
+			resp.Body.Close()
+			if cache == nil {
+				return nil, fmt.Errorf("feed returned 304 with no cached body")
+			}
+			return parseFeedBody(cache.Body)
+
+		case resp.StatusCode == http.StatusOK:
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("error reading feed body: %w", err)
+			}
+
+			entry := feedCacheEntry{
+				ETag:         resp.Header.Get("ETag"),
+				LastModified: resp.Header.Get("Last-Modified"),
+				MaxAge:       parseMaxAge(resp.Header.Get("Cache-Control")),
+				FetchedAt:    time.Now(),
+				Body:         body,
+			}
+			if err := saveFeedCacheEntry(feedURL, entry); err != nil {
+				return nil, fmt.Errorf("error saving feed cache: %w", err)
+			}
+			return parseFeedBody(body)
+
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			delay := parseRetryAfter(resp.Header.Get("Retry-After"))
+			if delay <= 0 {
+				delay = backoffDelay(attempt)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			lastErr = fmt.Errorf("feed returned status: %d, body: %s", resp.StatusCode, body)
+			time.Sleep(delay)
+			continue
+
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("feed returned status: %d, body: %s", resp.StatusCode, body)
+		}
+	}
+
+	return nil, fmt.Errorf("feed %q failed after %d retries: %w", feedURL, MaxRetries, lastErr)
+}
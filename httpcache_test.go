@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestClientForProxy(t *testing.T) {
+	client, err := clientForProxy("")
+	if err != nil {
+		t.Fatalf("clientForProxy(\"\"): %v", err)
+	}
+	if client != httpClient {
+		t.Errorf("clientForProxy(\"\") should return the shared httpClient")
+	}
+
+	client, err = clientForProxy("http://proxy.example.com:8080")
+	if err != nil {
+		t.Fatalf("clientForProxy(proxy): %v", err)
+	}
+	if client == httpClient {
+		t.Errorf("clientForProxy(proxy) should return a dedicated client")
+	}
+
+	if _, err := clientForProxy("://not-a-url"); err == nil {
+		t.Errorf("clientForProxy(invalid) should error")
+	}
+}
+
+func TestParseMaxAge(t *testing.T) {
+	cases := []struct {
+		header string
+		want   int
+	}{
+		{"", 0},
+		{"no-cache", 0},
+		{"max-age=60", 60},
+		{"public, max-age=120, must-revalidate", 120},
+		{"max-age=not-a-number", 0},
+	}
+
+	for _, c := range cases {
+		if got := parseMaxAge(c.header); got != c.want {
+			t.Errorf("parseMaxAge(%q) = %d, want %d", c.header, got, c.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v, want 0", got)
+	}
+	if got := parseRetryAfter("5"); got != 5*time.Second {
+		t.Errorf("parseRetryAfter(\"5\") = %v, want 5s", got)
+	}
+
+	future := time.Now().Add(10 * time.Second)
+	got := parseRetryAfter(future.UTC().Format(http.TimeFormat))
+	if got <= 0 || got > 11*time.Second {
+		t.Errorf("parseRetryAfter(HTTP-date) = %v, want ~10s", got)
+	}
+}
+
+const testRSSBody = `<?xml version="1.0"?>
+<rss><channel><item><title>T</title><link>https://example.com/a</link><description>D</description><pubDate>now</pubDate></item></channel></rss>`
+
+// withTempCacheDir isolates HTTP cache state per test.
+func withTempCacheDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+}
+
+func TestFetchFeedURL_200PopulatesCache(t *testing.T) {
+	withTempCacheDir(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testRSSBody))
+	}))
+	defer server.Close()
+
+	items, err := fetchFeedURL(context.Background(), server.URL, "")
+	if err != nil {
+		t.Fatalf("fetchFeedURL: %v", err)
+	}
+	if len(items) != 1 || items[0].Link != "https://example.com/a" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+
+	entry, err := loadFeedCacheEntry(server.URL)
+	if err != nil || entry == nil {
+		t.Fatalf("expected cache entry, got %+v, err %v", entry, err)
+	}
+	if entry.ETag != `"v1"` {
+		t.Errorf("ETag = %q, want %q", entry.ETag, `"v1"`)
+	}
+}
+
+func TestFetchFeedURL_304ReusesCachedBody(t *testing.T) {
+	withTempCacheDir(t)
+
+	var gotIfNoneMatch string
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(testRSSBody))
+			return
+		}
+		gotIfNoneMatch = r.Header.Get("If-None-Match")
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	if _, err := fetchFeedURL(context.Background(), server.URL, ""); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+
+	items, err := fetchFeedURL(context.Background(), server.URL, "")
+	if err != nil {
+		t.Fatalf("second fetch (304): %v", err)
+	}
+	if gotIfNoneMatch != `"v1"` {
+		t.Errorf("If-None-Match = %q, want %q", gotIfNoneMatch, `"v1"`)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected cached items to be reused, got %+v", items)
+	}
+}
+
+func TestFetchFeedURL_429RetriesWithRetryAfter(t *testing.T) {
+	withTempCacheDir(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testRSSBody))
+	}))
+	defer server.Close()
+
+	items, err := fetchFeedURL(context.Background(), server.URL, "")
+	if err != nil {
+		t.Fatalf("fetchFeedURL: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+}
+
+func TestFetchFeedURL_503RetriesWithRetryAfter(t *testing.T) {
+	withTempCacheDir(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testRSSBody))
+	}))
+	defer server.Close()
+
+	items, err := fetchFeedURL(context.Background(), server.URL, "")
+	if err != nil {
+		t.Fatalf("fetchFeedURL: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+}
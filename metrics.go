@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const DefaultMetricsPort = "9090"
+
+var (
+	fetchTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zerohedge_fetch_total",
+		Help: "Feed fetch attempts by feed and result (success/failure).",
+	}, []string{"feed", "result"})
+
+	fetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "zerohedge_fetch_duration_seconds",
+		Help: "Feed fetch latency in seconds.",
+	}, []string{"feed"})
+
+	translateTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zerohedge_translate_total",
+		Help: "Translation attempts by backend and result (success/failure).",
+	}, []string{"backend", "result"})
+
+	translateDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "zerohedge_translate_duration_seconds",
+		Help: "Translation latency in seconds, by backend.",
+	}, []string{"backend"})
+
+	translatedChars = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zerohedge_translated_characters",
+		Help:    "Number of characters submitted for translation, by backend.",
+		Buckets: prometheus.ExponentialBuckets(64, 2, 10),
+	}, []string{"backend"})
+
+	sendTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zerohedge_telegram_send_total",
+		Help: "Telegram send attempts by feed and result (success/failure).",
+	}, []string{"feed", "result"})
+
+	lastFetchSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zerohedge_last_fetch_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful fetch, by feed.",
+	}, []string{"feed"})
+
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zerohedge_queue_depth",
+		Help: "Unsent articles found in the most recent fetch, by feed.",
+	}, []string{"feed"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		fetchTotal,
+		fetchDuration,
+		translateTotal,
+		translateDuration,
+		translatedChars,
+		sendTotal,
+		lastFetchSuccessTimestamp,
+		queueDepth,
+	)
+}
+
+// feedHealth tracks the last successful fetch per feed for readiness checks,
+// independent of the Prometheus registry.
+type feedHealthTracker struct {
+	mu          sync.Mutex
+	lastSuccess map[string]time.Time
+}
+
+var feedHealth = &feedHealthTracker{lastSuccess: make(map[string]time.Time)}
+
+func (h *feedHealthTracker) markSuccess(feed string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastSuccess[feed] = time.Now()
+	lastFetchSuccessTimestamp.WithLabelValues(feed).SetToCurrentTime()
+}
+
+func (h *feedHealthTracker) lastSuccessAt(feed string) (time.Time, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t, ok := h.lastSuccess[feed]
+	return t, ok
+}
+
+// startMetricsServer exposes /metrics, /healthz, and /readyz until ctx is cancelled.
+func startMetricsServer(ctx context.Context, logger *slog.Logger, feeds []FeedConfig, port string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", readyzHandler(feeds))
+
+	srv := &http.Server{Addr: ":" + port, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("Starting metrics server", "port", port)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Error("Metrics server stopped", "err", err)
+	}
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+// readyzHandler builds the /readyz handler for a fixed feed set, flipping
+// unhealthy once staleFeeds reports anything.
+func readyzHandler(feeds []FeedConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if stale := staleFeeds(feeds); len(stale) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "stale feeds: %v\n", stale)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ready")
+	}
+}
+
+// staleFeeds returns the names of enabled feeds with no successful fetch
+// within twice their expected cron interval.
+func staleFeeds(feeds []FeedConfig) []string {
+	var stale []string
+	now := time.Now()
+
+	for _, feed := range feeds {
+		if !feed.Enabled {
+			continue
+		}
+
+		interval := estimatedInterval(feed.Cron, now)
+		threshold := 2 * interval
+
+		lastSuccess, ok := feedHealth.lastSuccessAt(feed.Name)
+		if !ok {
+			if now.Sub(processStart) > threshold {
+				stale = append(stale, feed.Name)
+			}
+			continue
+		}
+		if now.Sub(lastSuccess) > threshold {
+			stale = append(stale, feed.Name)
+		}
+	}
+	return stale
+}
+
+// estimatedInterval approximates a cron expression's run interval by
+// measuring the gap between its next two scheduled runs.
+func estimatedInterval(cronExpr string, after time.Time) time.Duration {
+	first, err := nextCronRun(cronExpr, after)
+	if err != nil {
+		return time.Hour
+	}
+	second, err := nextCronRun(cronExpr, first)
+	if err != nil {
+		return time.Hour
+	}
+	return second.Sub(first)
+}
+
+var processStart = time.Now()
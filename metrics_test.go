@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEstimatedInterval(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := estimatedInterval("*/5 * * * *", now)
+	if got != 5*time.Minute {
+		t.Errorf("estimatedInterval = %v, want 5m", got)
+	}
+}
+
+func TestStaleFeeds(t *testing.T) {
+	feedHealth = &feedHealthTracker{lastSuccess: make(map[string]time.Time)}
+	feed := FeedConfig{Name: "f", Cron: "*/1 * * * *", Enabled: true}
+
+	if stale := staleFeeds([]FeedConfig{feed}); len(stale) != 0 {
+		t.Fatalf("never-fetched feed within startup grace should not be stale, got %v", stale)
+	}
+
+	feedHealth.lastSuccess["f"] = time.Now().Add(-10 * time.Minute)
+	if stale := staleFeeds([]FeedConfig{feed}); len(stale) != 1 {
+		t.Fatalf("feed stale for 10m against a 1m cron should be reported stale, got %v", stale)
+	}
+
+	feedHealth.markSuccess("f")
+	if stale := staleFeeds([]FeedConfig{feed}); len(stale) != 0 {
+		t.Fatalf("freshly fetched feed should not be stale, got %v", stale)
+	}
+
+	disabled := FeedConfig{Name: "disabled", Cron: "*/1 * * * *", Enabled: false}
+	if stale := staleFeeds([]FeedConfig{disabled}); len(stale) != 0 {
+		t.Fatalf("disabled feeds should never be reported stale, got %v", stale)
+	}
+}
+
+func TestReadyzHandlerTransition(t *testing.T) {
+	feedHealth = &feedHealthTracker{lastSuccess: make(map[string]time.Time)}
+	feed := FeedConfig{Name: "f", Cron: "*/1 * * * *", Enabled: true}
+	handler := readyzHandler([]FeedConfig{feed})
+
+	feedHealth.markSuccess("f")
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != 200 {
+		t.Fatalf("expected 200 when feed is fresh, got %d", rec.Code)
+	}
+
+	feedHealth.lastSuccess["f"] = time.Now().Add(-10 * time.Minute)
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != 503 {
+		t.Fatalf("expected 503 once the feed goes stale, got %d", rec.Code)
+	}
+}
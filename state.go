@@ -0,0 +1,103 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MaxRecentHashes bounds how many article hashes are retained per feed for dedup.
+const MaxRecentHashes = 1000
+
+// FeedState tracks recently sent article hashes for a single feed, plus a
+// bloom filter for fast membership checks on hot paths, so that restarts
+// and out-of-order feeds don't lose or duplicate items.
+type FeedState struct {
+	Hashes []string     `json:"hashes"`
+	Bloom  *bloomFilter `json:"bloom"`
+	seen   map[string]bool
+}
+
+// hashArticle derives a stable dedup key from an article's link and pubDate,
+// so republished or reordered items with the same link but a new date are
+// still treated as new.
+func hashArticle(link, pubDate string) string {
+	sum := sha256.Sum256([]byte(link + "|" + pubDate))
+	return hex.EncodeToString(sum[:])
+}
+
+func feedStateFile(feedName string) string {
+	return filepath.Join(StateDir, feedName+".json")
+}
+
+// loadFeedState reads a feed's dedup state, returning an empty state if none exists yet.
+func loadFeedState(feedName string) (*FeedState, error) {
+	state := &FeedState{seen: make(map[string]bool), Bloom: newBloomFilter()}
+
+	data, err := os.ReadFile(feedStateFile(feedName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("read error: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("JSON decode error: %w", err)
+	}
+	if state.Bloom == nil {
+		state.Bloom = newBloomFilter()
+		for _, h := range state.Hashes {
+			state.Bloom.Add(h)
+		}
+	}
+
+	for _, h := range state.Hashes {
+		state.seen[h] = true
+	}
+	return state, nil
+}
+
+// saveFeedState persists a feed's dedup state to disk.
+func saveFeedState(feedName string, state *FeedState) error {
+	if err := os.MkdirAll(StateDir, 0755); err != nil {
+		return fmt.Errorf("error creating state dir: %w", err)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("serialization error: %w", err)
+	}
+
+	return os.WriteFile(feedStateFile(feedName), data, 0644)
+}
+
+// Seen reports whether hash has already been recorded. The bloom filter is
+// checked first for an O(1) membership test; only a bloom "maybe" falls
+// through to the exact hash set, which confirms or rules out a collision.
+func (s *FeedState) Seen(hash string) bool {
+	if !s.Bloom.MightContain(hash) {
+		return false
+	}
+	return s.seen[hash]
+}
+
+// Record marks hash as sent, evicting the oldest entry once the set exceeds
+// MaxRecentHashes. Bloom filter bits are never cleared on eviction; that only
+// costs an extra, harmless exact-set lookup on a future collision.
+func (s *FeedState) Record(hash string) {
+	if s.seen[hash] {
+		return
+	}
+	s.seen[hash] = true
+	s.Bloom.Add(hash)
+	s.Hashes = append(s.Hashes, hash)
+	if len(s.Hashes) > MaxRecentHashes {
+		oldest := s.Hashes[0]
+		s.Hashes = s.Hashes[1:]
+		delete(s.seen, oldest)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// withTempStateDir isolates feed state files per test.
+func withTempStateDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+}
+
+func TestHashArticle(t *testing.T) {
+	a := hashArticle("https://example.com/a", "2026-01-01")
+	b := hashArticle("https://example.com/a", "2026-01-02")
+	if a == b {
+		t.Error("a republished link with a new pubDate should hash differently")
+	}
+	if a != hashArticle("https://example.com/a", "2026-01-01") {
+		t.Error("hashArticle should be deterministic")
+	}
+}
+
+func TestFeedState_SeenAndRecord(t *testing.T) {
+	state, err := loadFeedState("nonexistent-feed")
+	if err != nil {
+		t.Fatalf("loadFeedState: %v", err)
+	}
+
+	hash := hashArticle("https://example.com/a", "2026-01-01")
+	if state.Seen(hash) {
+		t.Error("hash should not be seen before Record")
+	}
+
+	state.Record(hash)
+	if !state.Seen(hash) {
+		t.Error("hash should be seen after Record")
+	}
+}
+
+func TestFeedState_EvictsOldestBeyondMax(t *testing.T) {
+	state := &FeedState{seen: make(map[string]bool), Bloom: newBloomFilter()}
+
+	for i := 0; i < MaxRecentHashes+10; i++ {
+		state.Record(hashArticle("https://example.com/a", string(rune(i))))
+	}
+
+	if len(state.Hashes) != MaxRecentHashes {
+		t.Fatalf("len(Hashes) = %d, want %d", len(state.Hashes), MaxRecentHashes)
+	}
+
+	evicted := hashArticle("https://example.com/a", string(rune(0)))
+	if state.seen[evicted] {
+		t.Error("oldest hash should have been evicted from the exact set")
+	}
+}
+
+func TestSaveAndLoadFeedState_RoundTrip(t *testing.T) {
+	withTempStateDir(t)
+
+	state, err := loadFeedState("myfeed")
+	if err != nil {
+		t.Fatalf("loadFeedState: %v", err)
+	}
+	hash := hashArticle("https://example.com/a", "2026-01-01")
+	state.Record(hash)
+
+	if err := saveFeedState("myfeed", state); err != nil {
+		t.Fatalf("saveFeedState: %v", err)
+	}
+
+	reloaded, err := loadFeedState("myfeed")
+	if err != nil {
+		t.Fatalf("loadFeedState (reload): %v", err)
+	}
+	if !reloaded.Seen(hash) {
+		t.Error("hash recorded before saving should still be seen after reload")
+	}
+}
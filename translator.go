@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DeepLTranslate        = "https://api-free.deepl.com/v2/translate"
+	LibreTranslateDefault = "https://libretranslate.com/translate"
+	OpenAIChatCompletions = "https://api.openai.com/v1/chat/completions"
+)
+
+// Translator is implemented by every translation backend. srcLang may be
+// empty to request auto-detection where the backend supports it.
+type Translator interface {
+	Name() string
+	Translate(ctx context.Context, text, srcLang, dstLang string) (string, error)
+}
+
+// Summarizer is implemented by backends (e.g. LLM chat-completion adapters)
+// that can translate and summarize in a single round trip, letting callers
+// skip the separate intelligentSummary pass.
+type Summarizer interface {
+	TranslateAndSummarize(ctx context.Context, text, dstLang string, sentences int) (string, error)
+}
+
+var translators []Translator
+
+// buildTranslators assembles the ordered translator chain from env-provided
+// credentials, in the priority given by names (e.g. "deepl,libretranslate,yandex").
+func buildTranslators(names []string) []Translator {
+	available := map[string]Translator{
+		"yandex": yandexTranslator{},
+	}
+	if DeepLAPIKey != "" {
+		available["deepl"] = deeplTranslator{APIKey: DeepLAPIKey}
+	}
+	if LibreTranslateURL != "" {
+		available["libretranslate"] = libreTranslator{BaseURL: LibreTranslateURL, APIKey: LibreTranslateAPIKey}
+	}
+	if OpenAIAPIKey != "" {
+		available["openai"] = openAITranslator{
+			BaseURL: orDefault(OpenAIBaseURL, OpenAIChatCompletions),
+			APIKey:  OpenAIAPIKey,
+			Model:   orDefault(OpenAIModel, "gpt-4o-mini"),
+		}
+	}
+
+	var chain []Translator
+	for _, name := range names {
+		if t, ok := available[strings.TrimSpace(name)]; ok {
+			chain = append(chain, t)
+		}
+	}
+	return chain
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// translateText tries each configured translator in order, falling back to
+// the next backend on error (e.g. quota exhaustion or a 5xx response).
+func translateText(ctx context.Context, logger *slog.Logger, text, srcLang, dstLang string) (string, error) {
+	return translateTextChain(ctx, logger, translators, text, srcLang, dstLang)
+}
+
+// translateTextChain is translateText over an explicit chain, letting callers
+// that already tried translators[0] skip re-trying it in the fallback chain.
+func translateTextChain(ctx context.Context, logger *slog.Logger, chain []Translator, text, srcLang, dstLang string) (string, error) {
+	var lastErr error
+	for _, t := range chain {
+		start := time.Now()
+		result, err := t.Translate(ctx, text, srcLang, dstLang)
+		translateDuration.WithLabelValues(t.Name()).Observe(time.Since(start).Seconds())
+		if err != nil {
+			translateTotal.WithLabelValues(t.Name(), "failure").Inc()
+			logger.Warn("Translator backend failed, trying next", "backend", t.Name(), "err", err)
+			lastErr = err
+			continue
+		}
+		translateTotal.WithLabelValues(t.Name(), "success").Inc()
+		translatedChars.WithLabelValues(t.Name()).Observe(float64(len(text)))
+		return result, nil
+	}
+	return "", fmt.Errorf("all translation backends failed: %w", lastErr)
+}
+
+// translateAndSummarize produces a translated, summary-length version of text.
+// If the first configured backend can translate and summarize in one pass
+// (e.g. an LLM chat-completions adapter), that is used directly; on failure
+// it falls back to translateText (trying the remaining backends in order)
+// followed by intelligentSummary, matching translateText's own failover.
+func translateAndSummarize(ctx context.Context, logger *slog.Logger, text, srcLang, dstLang string) (string, error) {
+	fallbackChain := translators
+	if len(translators) > 0 {
+		if s, ok := translators[0].(Summarizer); ok {
+			name := translators[0].Name()
+			start := time.Now()
+			result, err := s.TranslateAndSummarize(ctx, text, dstLang, SummarySentences)
+			translateDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+			if err != nil {
+				translateTotal.WithLabelValues(name, "failure").Inc()
+				logger.Warn("Summarizer backend failed, falling back to translateText", "backend", name, "err", err)
+				fallbackChain = translators[1:]
+			} else {
+				translateTotal.WithLabelValues(name, "success").Inc()
+				translatedChars.WithLabelValues(name).Observe(float64(len(text)))
+				return result, nil
+			}
+		}
+	}
+
+	translation, err := translateTextChain(ctx, logger, fallbackChain, text, srcLang, dstLang)
+	if err != nil {
+		return "", err
+	}
+	return intelligentSummary(translation), nil
+}
+
+// yandexTranslator adapts the existing Yandex Cloud Translate call.
+type yandexTranslator struct{}
+
+func (yandexTranslator) Name() string { return "yandex" }
+
+func (yandexTranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	return translateWithYandex(ctx, text, dstLang)
+}
+
+// deeplTranslator adapts the DeepL REST API.
+type deeplTranslator struct {
+	APIKey string
+}
+
+func (deeplTranslator) Name() string { return "deepl" }
+
+func (d deeplTranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	form := url.Values{}
+	form.Set("text", text)
+	form.Set("target_lang", strings.ToUpper(dstLang))
+	if srcLang != "" {
+		form.Set("source_lang", strings.ToUpper(srcLang))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", DeepLTranslate, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("request creation error: %w", err)
+	}
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+d.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("DeepL API error: status %d, body: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("JSON decode error: %w, body: %s", err, body)
+	}
+	if len(result.Translations) == 0 {
+		return "", fmt.Errorf("empty translations in response: %s", body)
+	}
+	return result.Translations[0].Text, nil
+}
+
+// libreTranslator adapts a self-hosted or public LibreTranslate instance.
+type libreTranslator struct {
+	BaseURL string
+	APIKey  string
+}
+
+func (libreTranslator) Name() string { return "libretranslate" }
+
+func (l libreTranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	source := srcLang
+	if source == "" {
+		source = "auto"
+	}
+	payload := map[string]string{
+		"q":      text,
+		"source": source,
+		"target": dstLang,
+		"format": "text",
+	}
+	if l.APIKey != "" {
+		payload["api_key"] = l.APIKey
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("serialization error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", l.BaseURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("request creation error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("LibreTranslate API error: status %d, body: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("JSON decode error: %w, body: %s", err, body)
+	}
+	return result.TranslatedText, nil
+}
+
+// openAITranslator adapts any OpenAI-compatible /v1/chat/completions
+// endpoint (OpenAI itself, or a self-hosted Ollama server), and can
+// translate and summarize in a single prompt.
+type openAITranslator struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+}
+
+func (openAITranslator) Name() string { return "openai" }
+
+func (o openAITranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	prompt := fmt.Sprintf("Translate the following text to %s. Respond with only the translation:\n\n%s", dstLang, text)
+	return o.complete(ctx, prompt)
+}
+
+func (o openAITranslator) TranslateAndSummarize(ctx context.Context, text, dstLang string, sentences int) (string, error) {
+	prompt := fmt.Sprintf("Translate and summarize to %s in %d sentences:\n\n%s", dstLang, sentences, text)
+	return o.complete(ctx, prompt)
+}
+
+func (o openAITranslator) complete(ctx context.Context, prompt string) (string, error) {
+	payload := map[string]interface{}{
+		"model": o.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("serialization error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.BaseURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("request creation error: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("OpenAI-compatible API error: status %d, body: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("JSON decode error: %w, body: %s", err, body)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("empty choices in response: %s", body)
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type stubTranslator struct {
+	name   string
+	result string
+	err    error
+}
+
+func (s stubTranslator) Name() string { return s.name }
+
+func (s stubTranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	return s.result, nil
+}
+
+type stubSummarizer struct {
+	stubTranslator
+	summary    string
+	summaryErr error
+}
+
+func (s stubSummarizer) TranslateAndSummarize(ctx context.Context, text, dstLang string, sentences int) (string, error) {
+	if s.summaryErr != nil {
+		return "", s.summaryErr
+	}
+	return s.summary, nil
+}
+
+func TestBuildTranslators_PriorityOrder(t *testing.T) {
+	DeepLAPIKey = "key"
+	defer func() { DeepLAPIKey = "" }()
+
+	chain := buildTranslators([]string{"deepl", "yandex"})
+	if len(chain) != 2 || chain[0].Name() != "deepl" || chain[1].Name() != "yandex" {
+		t.Fatalf("unexpected chain order: %v", chain)
+	}
+
+	chain = buildTranslators([]string{"libretranslate", "yandex"})
+	if len(chain) != 1 || chain[0].Name() != "yandex" {
+		t.Fatalf("unconfigured backends should be skipped, got %v", chain)
+	}
+}
+
+func TestTranslateText_FallsBackOnError(t *testing.T) {
+	translators = []Translator{
+		stubTranslator{name: "a", err: errors.New("quota exceeded")},
+		stubTranslator{name: "b", result: "translated"},
+	}
+	defer func() { translators = nil }()
+
+	got, err := translateText(context.Background(), testLogger(), "hello", "en", "ru")
+	if err != nil {
+		t.Fatalf("translateText: %v", err)
+	}
+	if got != "translated" {
+		t.Errorf("got %q, want %q", got, "translated")
+	}
+}
+
+func TestTranslateText_AllBackendsFail(t *testing.T) {
+	translators = []Translator{
+		stubTranslator{name: "a", err: errors.New("down")},
+	}
+	defer func() { translators = nil }()
+
+	if _, err := translateText(context.Background(), testLogger(), "hello", "en", "ru"); err == nil {
+		t.Error("expected an error when every backend fails")
+	}
+}
+
+// When translators[0]'s Summarizer path fails, the fallback must not retry
+// translators[0] a second time via its plain Translate method.
+func TestTranslateAndSummarize_SkipsFailedSummarizerInFallback(t *testing.T) {
+	translators = []Translator{
+		stubSummarizer{
+			stubTranslator: stubTranslator{name: "a", result: "should not be used"},
+			summaryErr:     errors.New("summarizer down"),
+		},
+		stubTranslator{name: "b", result: "fallback translation"},
+	}
+	defer func() { translators = nil }()
+
+	got, err := translateAndSummarize(context.Background(), testLogger(), "hello world.", "en", "ru")
+	if err != nil {
+		t.Fatalf("translateAndSummarize: %v", err)
+	}
+	if got != "fallback translation" {
+		t.Errorf("got %q, want the fallback backend's result, not translators[0] retried", got)
+	}
+}
+
+func TestTranslateAndSummarize_UsesSummarizerOnSuccess(t *testing.T) {
+	translators = []Translator{
+		stubSummarizer{stubTranslator: stubTranslator{name: "a"}, summary: "summarized"},
+	}
+	defer func() { translators = nil }()
+
+	got, err := translateAndSummarize(context.Background(), testLogger(), "hello world.", "en", "ru")
+	if err != nil {
+		t.Fatalf("translateAndSummarize: %v", err)
+	}
+	if got != "summarized" {
+		t.Errorf("got %q, want %q", got, "summarized")
+	}
+}